@@ -0,0 +1,192 @@
+// Copyright 2022 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/schema"
+)
+
+// Recognised values for SecretAuditEvent.Action().
+const (
+	SecretActionCreated   = "created"
+	SecretActionUpdated   = "updated"
+	SecretActionRotated   = "rotated"
+	SecretActionGranted   = "granted"
+	SecretActionRevoked   = "revoked"
+	SecretActionConsumed  = "consumed"
+	SecretActionExpired   = "expired"
+	SecretActionObsoleted = "obsoleted"
+)
+
+// SecretAuditEvent represents a single entry in a secret's rotation and
+// access audit trail.
+type SecretAuditEvent interface {
+	Time() time.Time
+	Actor() (names.Tag, error)
+	Action() string
+	RevisionNumber() int
+	Detail() map[string]string
+}
+
+type secretAuditEvent struct {
+	Time_           time.Time         `yaml:"time"`
+	Actor_          string            `yaml:"actor"`
+	Action_         string            `yaml:"action"`
+	RevisionNumber_ int               `yaml:"revision-number"`
+	Detail_         map[string]string `yaml:"detail,omitempty"`
+}
+
+// SecretAuditEventArgs is an argument struct used to create a new internal
+// secret audit event type that supports the SecretAuditEvent interface.
+type SecretAuditEventArgs struct {
+	Time           time.Time
+	Actor          names.Tag
+	Action         string
+	RevisionNumber int
+	Detail         map[string]string
+}
+
+func newSecretAuditEvent(args SecretAuditEventArgs) *secretAuditEvent {
+	event := &secretAuditEvent{
+		Time_:           args.Time.UTC(),
+		Action_:         args.Action,
+		RevisionNumber_: args.RevisionNumber,
+		Detail_:         args.Detail,
+	}
+	if args.Actor != nil {
+		event.Actor_ = args.Actor.String()
+	}
+	return event
+}
+
+// Time implements SecretAuditEvent.
+func (e *secretAuditEvent) Time() time.Time {
+	return e.Time_
+}
+
+// Actor implements SecretAuditEvent.
+func (e *secretAuditEvent) Actor() (names.Tag, error) {
+	if e.Actor_ == "" {
+		return nil, nil
+	}
+	tag, err := names.ParseTag(e.Actor_)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return tag, nil
+}
+
+// Action implements SecretAuditEvent.
+func (e *secretAuditEvent) Action() string {
+	return e.Action_
+}
+
+// RevisionNumber implements SecretAuditEvent.
+func (e *secretAuditEvent) RevisionNumber() int {
+	return e.RevisionNumber_
+}
+
+// Detail implements SecretAuditEvent.
+func (e *secretAuditEvent) Detail() map[string]string {
+	var result map[string]string
+	if len(e.Detail_) == 0 {
+		return result
+	}
+	result = make(map[string]string)
+	for k, v := range e.Detail_ {
+		result[k] = v
+	}
+	return result
+}
+
+var validSecretAuditActions = map[string]bool{
+	SecretActionCreated:   true,
+	SecretActionUpdated:   true,
+	SecretActionRotated:   true,
+	SecretActionGranted:   true,
+	SecretActionRevoked:   true,
+	SecretActionConsumed:  true,
+	SecretActionExpired:   true,
+	SecretActionObsoleted: true,
+}
+
+// validate checks that the event's action is recognised, its actor tag
+// parses, and its revision number refers to a revision that exists on the
+// owning secret.
+func (e *secretAuditEvent) validate(knownRevisions map[int]bool) error {
+	if !validSecretAuditActions[e.Action_] {
+		return errors.NotValidf("secret audit event action %q", e.Action_)
+	}
+	if _, err := e.Actor(); err != nil {
+		return errors.Wrap(err, errors.NotValidf("secret audit event actor %q", e.Actor_))
+	}
+	if !knownRevisions[e.RevisionNumber_] {
+		return errors.NotValidf("secret audit event revision %d", e.RevisionNumber_)
+	}
+	return nil
+}
+
+func importSecretAuditEvents(source map[string]interface{}, version int) ([]*secretAuditEvent, error) {
+	raw, ok := source["audit-events"]
+	if !ok {
+		return nil, nil
+	}
+	importFunc, ok := secretAuditEventDeserializationFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
+	}
+	sourceList := raw.([]interface{})
+	result := make([]*secretAuditEvent, 0, len(sourceList))
+	for i, value := range sourceList {
+		itemSource, ok := value.(map[interface{}]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected value for audit event %d, %T", i, value)
+		}
+		event, err := importFunc(itemSource)
+		if err != nil {
+			return nil, errors.Annotatef(err, "audit event %d", i)
+		}
+		result = append(result, event)
+	}
+	return result, nil
+}
+
+type secretAuditEventDeserializationFunc func(map[interface{}]interface{}) (*secretAuditEvent, error)
+
+var secretAuditEventDeserializationFuncs = map[int]secretAuditEventDeserializationFunc{
+	2: importSecretAuditEventV2,
+}
+
+func importSecretAuditEventV2(source map[interface{}]interface{}) (*secretAuditEvent, error) {
+	fields := schema.Fields{
+		"time":            schema.Time(),
+		"actor":           schema.String(),
+		"action":          schema.String(),
+		"revision-number": schema.Int(),
+		"detail":          schema.StringMap(schema.String()),
+	}
+	defaults := schema.Defaults{
+		"detail": schema.Omit,
+	}
+
+	checker := schema.FieldMap(fields, defaults)
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "audit events v2 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	event := &secretAuditEvent{
+		Time_:           valid["time"].(time.Time).UTC(),
+		Actor_:          valid["actor"].(string),
+		Action_:         valid["action"].(string),
+		RevisionNumber_: int(valid["revision-number"].(int64)),
+		Detail_:         convertToStringMap(valid["detail"]),
+	}
+	return event, nil
+}
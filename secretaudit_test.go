@@ -0,0 +1,113 @@
+// Copyright 2022 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"testing"
+	"time"
+
+	"github.com/juju/names/v4"
+)
+
+func newTestSecretWithAudit(t *testing.T, events []SecretAuditEventArgs) *secret {
+	t.Helper()
+	owner := names.NewApplicationTag("mysql")
+	return newSecret(SecretArgs{
+		ID:    "9m4e2mr0ui3e8a215n4g",
+		Owner: owner,
+		Revisions: []SecretRevisionArgs{
+			{Number: 1},
+			{Number: 2},
+		},
+		AuditEvents: events,
+	})
+}
+
+func TestSecretAuditEventsRoundTrip(t *testing.T) {
+	actor := names.NewUnitTag("mysql/0")
+	want := []SecretAuditEventArgs{
+		{
+			Time:           time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			Actor:          actor,
+			Action:         SecretActionCreated,
+			RevisionNumber: 1,
+		},
+		{
+			Time:           time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+			Actor:          actor,
+			Action:         SecretActionRotated,
+			RevisionNumber: 2,
+			Detail:         map[string]string{"reason": "scheduled"},
+		},
+	}
+	s := newTestSecretWithAudit(t, want)
+
+	got := s.AuditEvents()
+	if len(got) != len(want) {
+		t.Fatalf("got %d audit events, want %d", len(got), len(want))
+	}
+	for i, event := range got {
+		if !event.Time().Equal(want[i].Time) {
+			t.Errorf("event %d: time = %v, want %v", i, event.Time(), want[i].Time)
+		}
+		gotActor, err := event.Actor()
+		if err != nil {
+			t.Fatalf("event %d: Actor() error: %v", i, err)
+		}
+		if gotActor.String() != actor.String() {
+			t.Errorf("event %d: actor = %v, want %v", i, gotActor, actor)
+		}
+		if event.Action() != want[i].Action {
+			t.Errorf("event %d: action = %q, want %q", i, event.Action(), want[i].Action)
+		}
+		if event.RevisionNumber() != want[i].RevisionNumber {
+			t.Errorf("event %d: revision = %d, want %d", i, event.RevisionNumber(), want[i].RevisionNumber)
+		}
+	}
+}
+
+func TestSecretAuditEventValidation(t *testing.T) {
+	actor := names.NewUnitTag("mysql/0")
+
+	tests := []struct {
+		name    string
+		events  []SecretAuditEventArgs
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			events: []SecretAuditEventArgs{
+				{Time: time.Now(), Actor: actor, Action: SecretActionCreated, RevisionNumber: 1},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unrecognised action",
+			events: []SecretAuditEventArgs{
+				{Time: time.Now(), Actor: actor, Action: "not-a-real-action", RevisionNumber: 1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown revision",
+			events: []SecretAuditEventArgs{
+				{Time: time.Now(), Actor: actor, Action: SecretActionCreated, RevisionNumber: 99},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestSecretWithAudit(t, test.events)
+			err := s.Validate()
+			if test.wantErr && err == nil {
+				t.Fatalf("expected validation error, got nil")
+			}
+			if !test.wantErr && err != nil {
+				t.Fatalf("unexpected validation error: %v", err)
+			}
+		})
+	}
+}
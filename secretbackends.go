@@ -0,0 +1,264 @@
+// Copyright 2022 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+)
+
+// Recognised values for SecretBackend.BackendType().
+const (
+	SecretBackendTypeController = "controller"
+	SecretBackendTypeKubernetes = "kubernetes"
+	SecretBackendTypeVault      = "vault"
+)
+
+// SecretBackend represents the definition of a secret backend, i.e. the
+// external (or internal) store that actually holds secret content on
+// behalf of a revision.
+type SecretBackend interface {
+	ID() string
+	Name() string
+	BackendType() string
+	Config() map[string]string
+
+	TokenRotateInterval() *time.Duration
+	NextRotateTime() *time.Time
+}
+
+type secretBackends struct {
+	Version         int              `yaml:"version"`
+	SecretBackends_ []*secretBackend `yaml:"secret-backends"`
+}
+
+type secretBackend struct {
+	ID_          string            `yaml:"id"`
+	Name_        string            `yaml:"name"`
+	BackendType_ string            `yaml:"backend-type"`
+	Config_      map[string]string `yaml:"config,omitempty"`
+
+	// TokenRotateInterval_ is stored as a duration string (e.g. "24h0m0s")
+	// rather than a time.Duration, which yaml marshals as a bare integer
+	// of nanoseconds and so would not round-trip against the "string"
+	// schema type used on import.
+	TokenRotateInterval_ *string    `yaml:"token-rotate-interval,omitempty"`
+	NextRotateTime_      *time.Time `yaml:"next-rotate-time,omitempty"`
+}
+
+// SecretBackendArgs is an argument struct used to create a
+// new internal secret backend type that supports the secret backend interface.
+type SecretBackendArgs struct {
+	ID          string
+	Name        string
+	BackendType string
+	Config      map[string]string
+
+	TokenRotateInterval *time.Duration
+	NextRotateTime      *time.Time
+}
+
+func newSecretBackend(args SecretBackendArgs) *secretBackend {
+	backend := &secretBackend{
+		ID_:          args.ID,
+		Name_:        args.Name,
+		BackendType_: args.BackendType,
+		Config_:      args.Config,
+	}
+	if args.TokenRotateInterval != nil {
+		interval := args.TokenRotateInterval.String()
+		backend.TokenRotateInterval_ = &interval
+	}
+	if args.NextRotateTime != nil {
+		next := args.NextRotateTime.UTC()
+		backend.NextRotateTime_ = &next
+	}
+	return backend
+}
+
+// ID implements SecretBackend.
+func (b *secretBackend) ID() string {
+	return b.ID_
+}
+
+// Name implements SecretBackend.
+func (b *secretBackend) Name() string {
+	return b.Name_
+}
+
+// BackendType implements SecretBackend.
+func (b *secretBackend) BackendType() string {
+	return b.BackendType_
+}
+
+// Config implements SecretBackend.
+func (b *secretBackend) Config() map[string]string {
+	var result map[string]string
+	if len(b.Config_) == 0 {
+		return result
+	}
+	result = make(map[string]string)
+	for k, v := range b.Config_ {
+		result[k] = v
+	}
+	return result
+}
+
+// TokenRotateInterval implements SecretBackend.
+func (b *secretBackend) TokenRotateInterval() *time.Duration {
+	if b.TokenRotateInterval_ == nil {
+		return nil
+	}
+	interval, err := time.ParseDuration(*b.TokenRotateInterval_)
+	if err != nil {
+		return nil
+	}
+	return &interval
+}
+
+// NextRotateTime implements SecretBackend.
+func (b *secretBackend) NextRotateTime() *time.Time {
+	return b.NextRotateTime_
+}
+
+var validSecretBackendTypes = map[string]bool{
+	SecretBackendTypeController: true,
+	SecretBackendTypeKubernetes: true,
+	SecretBackendTypeVault:      true,
+}
+
+// Validate checks that the backend's required fields are set, its type is
+// recognised and, if set, its token rotate interval is a parseable
+// duration.
+func (b *secretBackend) Validate() error {
+	if b.ID_ == "" {
+		return errors.NotValidf("secret backend missing id")
+	}
+	if b.Name_ == "" {
+		return errors.NotValidf("secret backend %q missing name", b.ID_)
+	}
+	if !validSecretBackendTypes[b.BackendType_] {
+		return errors.NotValidf("secret backend %q type %q", b.ID_, b.BackendType_)
+	}
+	if b.TokenRotateInterval_ != nil {
+		if _, err := time.ParseDuration(*b.TokenRotateInterval_); err != nil {
+			return errors.Wrap(err, errors.NotValidf("secret backend %q token-rotate-interval", b.ID_))
+		}
+	}
+	return nil
+}
+
+// Validate checks that every backend in the collection is itself valid.
+func (s *secretBackends) Validate() error {
+	for _, b := range s.SecretBackends_ {
+		if err := b.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// validateSecretBackendRefs checks that every BackendId referenced by a
+// secret revision resolves to one of the known backends. It is intended to
+// be called from the model's top-level Validate() alongside the secrets and
+// secret backends collections.
+func validateSecretBackendRefs(secrets []*secret, backends []*secretBackend) error {
+	known := make(map[string]bool, len(backends))
+	for _, b := range backends {
+		known[b.ID_] = true
+	}
+	for _, s := range secrets {
+		for _, rev := range s.Revisions_ {
+			if rev.BackendId_ == nil {
+				continue
+			}
+			if !known[*rev.BackendId_] {
+				return errors.NotValidf("secret %q revision %d references unknown backend %q", s.ID_, rev.Number_, *rev.BackendId_)
+			}
+		}
+	}
+	return nil
+}
+
+func importSecretBackends(source map[string]interface{}) ([]*secretBackend, error) {
+	checker := versionedChecker("secret-backends")
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "secret backends version schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+
+	version := int(valid["version"].(int64))
+	sourceList := valid["secret-backends"].([]interface{})
+	return importSecretBackendList(sourceList, version)
+}
+
+func importSecretBackendList(sourceList []interface{}, version int) ([]*secretBackend, error) {
+	getFields, ok := secretBackendFieldsFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
+	}
+
+	result := make([]*secretBackend, 0, len(sourceList))
+	for i, value := range sourceList {
+		source, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected value for secret backend %d, %T", i, value)
+		}
+		backend, err := importSecretBackend(source, version, getFields)
+		if err != nil {
+			return nil, errors.Annotatef(err, "secret backend %d", i)
+		}
+		result = append(result, backend)
+	}
+	return result, nil
+}
+
+var secretBackendFieldsFuncs = map[int]fieldsFunc{
+	1: secretBackendV1Fields,
+}
+
+func secretBackendV1Fields() (schema.Fields, schema.Defaults) {
+	fields := schema.Fields{
+		"id":                    schema.String(),
+		"name":                  schema.String(),
+		"backend-type":          schema.String(),
+		"config":                schema.StringMap(schema.String()),
+		"token-rotate-interval": schema.String(),
+		"next-rotate-time":      schema.Time(),
+	}
+	defaults := schema.Defaults{
+		"config":                schema.Omit,
+		"token-rotate-interval": schema.Omit,
+		"next-rotate-time":      schema.Omit,
+	}
+	return fields, defaults
+}
+
+func importSecretBackend(source map[string]interface{}, importVersion int, fieldFunc func() (schema.Fields, schema.Defaults)) (*secretBackend, error) {
+	fields, defaults := fieldFunc()
+	checker := schema.FieldMap(fields, defaults)
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "secret backend v%d schema check failed", importVersion)
+	}
+	valid := coerced.(map[string]interface{})
+	backend := &secretBackend{
+		ID_:             valid["id"].(string),
+		Name_:           valid["name"].(string),
+		BackendType_:    valid["backend-type"].(string),
+		Config_:         convertToStringMap(valid["config"]),
+		NextRotateTime_: fieldToTimePtr(valid, "next-rotate-time"),
+	}
+	if raw, ok := valid["token-rotate-interval"].(string); ok && raw != "" {
+		if _, err := time.ParseDuration(raw); err != nil {
+			return nil, errors.Annotatef(err, "secret backend %q token-rotate-interval", backend.ID_)
+		}
+		backend.TokenRotateInterval_ = &raw
+	}
+	return backend, nil
+}
@@ -0,0 +1,193 @@
+// Copyright 2022 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"testing"
+	"time"
+
+	"github.com/juju/names/v4"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const secretBackendsYAML = `
+version: 1
+secret-backends:
+- id: backend-a
+  name: myvault
+  backend-type: vault
+  config:
+    endpoint: https://vault.example.com
+  token-rotate-interval: 24h0m0s
+  next-rotate-time: 2023-01-01T00:00:00Z
+`
+
+func parseSecretBackendsYAML(t *testing.T, raw string) []*secretBackend {
+	t.Helper()
+	var parsed map[string]interface{}
+	if err := yaml.Unmarshal([]byte(raw), &parsed); err != nil {
+		t.Fatalf("unmarshal fixture: %v", err)
+	}
+	backends, err := importSecretBackends(parsed)
+	if err != nil {
+		t.Fatalf("importSecretBackends: %v", err)
+	}
+	return backends
+}
+
+func TestImportSecretBackends(t *testing.T) {
+	backends := parseSecretBackendsYAML(t, secretBackendsYAML)
+	if len(backends) != 1 {
+		t.Fatalf("got %d backends, want 1", len(backends))
+	}
+	b := backends[0]
+	if b.ID() != "backend-a" {
+		t.Errorf("ID() = %q", b.ID())
+	}
+	if b.Name() != "myvault" {
+		t.Errorf("Name() = %q", b.Name())
+	}
+	if b.BackendType() != SecretBackendTypeVault {
+		t.Errorf("BackendType() = %q", b.BackendType())
+	}
+	if got := b.Config()["endpoint"]; got != "https://vault.example.com" {
+		t.Errorf("Config()[endpoint] = %q", got)
+	}
+	interval := b.TokenRotateInterval()
+	if interval == nil || *interval != 24*time.Hour {
+		t.Fatalf("TokenRotateInterval() = %v, want 24h", interval)
+	}
+	if b.NextRotateTime() == nil || !b.NextRotateTime().Equal(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("NextRotateTime() = %v", b.NextRotateTime())
+	}
+	if err := b.Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+}
+
+// TestSecretBackendExportImportRoundTrip checks that TokenRotateInterval_,
+// stored internally as a duration string to avoid yaml marshalling a
+// time.Duration as a bare integer, survives an export/import cycle.
+func TestSecretBackendExportImportRoundTrip(t *testing.T) {
+	original := parseSecretBackendsYAML(t, secretBackendsYAML)[0]
+
+	exported := secretBackends{
+		Version:         1,
+		SecretBackends_: []*secretBackend{original},
+	}
+	data, err := yaml.Marshal(&exported)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var reparsed map[string]interface{}
+	if err := yaml.Unmarshal(data, &reparsed); err != nil {
+		t.Fatalf("unmarshal exported yaml: %v", err)
+	}
+
+	roundTripped, err := importSecretBackends(reparsed)
+	if err != nil {
+		t.Fatalf("re-import: %v", err)
+	}
+	if len(roundTripped) != 1 {
+		t.Fatalf("got %d backends, want 1", len(roundTripped))
+	}
+	rt := roundTripped[0]
+
+	if rt.ID() != original.ID() {
+		t.Errorf("ID() = %q, want %q", rt.ID(), original.ID())
+	}
+	gotInterval, wantInterval := rt.TokenRotateInterval(), original.TokenRotateInterval()
+	if gotInterval == nil || wantInterval == nil || *gotInterval != *wantInterval {
+		t.Fatalf("TokenRotateInterval() = %v, want %v", gotInterval, wantInterval)
+	}
+	if err := rt.Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+}
+
+func TestSecretBackendValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		backend *secretBackend
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			backend: newSecretBackend(SecretBackendArgs{
+				ID:          "backend-a",
+				Name:        "myvault",
+				BackendType: SecretBackendTypeVault,
+			}),
+			wantErr: false,
+		},
+		{
+			name: "unknown backend type",
+			backend: newSecretBackend(SecretBackendArgs{
+				ID:          "backend-a",
+				Name:        "myvault",
+				BackendType: "not-a-real-backend",
+			}),
+			wantErr: true,
+		},
+		{
+			name: "unparseable token-rotate-interval",
+			backend: func() *secretBackend {
+				b := newSecretBackend(SecretBackendArgs{
+					ID:          "backend-a",
+					Name:        "myvault",
+					BackendType: SecretBackendTypeVault,
+				})
+				bogus := "not-a-duration"
+				b.TokenRotateInterval_ = &bogus
+				return b
+			}(),
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.backend.Validate()
+			if test.wantErr && err == nil {
+				t.Fatalf("expected validation error, got nil")
+			}
+			if !test.wantErr && err != nil {
+				t.Fatalf("unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestSecretBackendsCollectionValidate(t *testing.T) {
+	good := newSecretBackend(SecretBackendArgs{ID: "backend-a", Name: "myvault", BackendType: SecretBackendTypeVault})
+	bad := newSecretBackend(SecretBackendArgs{ID: "backend-b", Name: "", BackendType: SecretBackendTypeVault})
+
+	if err := (&secretBackends{SecretBackends_: []*secretBackend{good}}).Validate(); err != nil {
+		t.Fatalf("Validate() = %v, want nil", err)
+	}
+	if err := (&secretBackends{SecretBackends_: []*secretBackend{good, bad}}).Validate(); err == nil {
+		t.Fatalf("expected validation error for collection containing an invalid backend")
+	}
+}
+
+func TestValidateSecretBackendRefs(t *testing.T) {
+	backendID := "backend-a"
+	owner := names.NewApplicationTag("mysql")
+	s := newSecret(SecretArgs{
+		ID:    "9m4e2mr0ui3e8a215n4g",
+		Owner: owner,
+		Revisions: []SecretRevisionArgs{
+			{Number: 1, BackendId: &backendID},
+		},
+	})
+	known := newSecretBackend(SecretBackendArgs{ID: backendID, Name: "myvault", BackendType: SecretBackendTypeVault})
+
+	if err := validateSecretBackendRefs([]*secret{s}, []*secretBackend{known}); err != nil {
+		t.Fatalf("validateSecretBackendRefs() = %v, want nil", err)
+	}
+	if err := validateSecretBackendRefs([]*secret{s}, nil); err == nil {
+		t.Fatalf("expected error for dangling backend reference")
+	}
+}
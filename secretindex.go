@@ -0,0 +1,182 @@
+// Copyright 2022 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/names/v4"
+)
+
+// secretIndexFunc computes the set of index keys a secret should be filed
+// under for a particular index.
+type secretIndexFunc func(s *secret) []string
+
+// SecretIndex maintains inverted indexes over a collection of secrets, so
+// callers can look secrets up by id, owner, label, consumer or backend
+// without a linear scan. It is modelled on the index-function/key-set
+// pattern used by Kubernetes' client-go cache.Indexer: each named index is
+// built from a function that maps a secret to the keys it should be filed
+// under, and AddIndexers lets downstream callers register their own.
+type SecretIndex struct {
+	secrets  []*secret
+	indexers map[string]secretIndexFunc
+	indexes  map[string]map[string][]*secret
+}
+
+// NewSecretIndex builds a SecretIndex over secrets, with the standard
+// ByOwner, ByLabel, ByConsumer and ByBackend indexes already registered.
+func NewSecretIndex(secrets []Secret) *SecretIndex {
+	concrete := make([]*secret, 0, len(secrets))
+	for _, s := range secrets {
+		if cs, ok := s.(*secret); ok {
+			concrete = append(concrete, cs)
+		}
+	}
+	idx := &SecretIndex{
+		secrets:  concrete,
+		indexers: make(map[string]secretIndexFunc),
+		indexes:  make(map[string]map[string][]*secret),
+	}
+	for _, s := range concrete {
+		s.index_ = idx
+	}
+	idx.AddIndexers(map[string]secretIndexFunc{
+		"id":       idIndexFunc,
+		"owner":    ownerIndexFunc,
+		"label":    labelIndexFunc,
+		"consumer": consumerIndexFunc,
+		"backend":  backendIndexFunc,
+	})
+	return idx
+}
+
+// BuildSecretIndex builds a SecretIndex over this collection's secrets. It
+// is the explicit, on-demand counterpart to building an index lazily on
+// first query.
+func (s *secrets) BuildSecretIndex() *SecretIndex {
+	all := make([]Secret, len(s.Secrets_))
+	for i, sec := range s.Secrets_ {
+		all[i] = sec
+	}
+	return NewSecretIndex(all)
+}
+
+// AddIndexers registers additional named index functions, allowing
+// downstream callers (e.g. Juju's state layer) to index secrets on their
+// own criteria. Registering an indexer invalidates any previously built
+// index of the same name so it is rebuilt lazily on next use.
+func (idx *SecretIndex) AddIndexers(indexers map[string]secretIndexFunc) {
+	for name, fn := range indexers {
+		idx.indexers[name] = fn
+		delete(idx.indexes, name)
+	}
+}
+
+// invalidate drops all built indexes, forcing them to be rebuilt lazily
+// against the current secret set. It is called whenever the underlying
+// secrets are mutated (setRevisions, setConsumers, SetACL).
+func (idx *SecretIndex) invalidate() {
+	idx.indexes = make(map[string]map[string][]*secret)
+}
+
+func (idx *SecretIndex) byIndex(name, key string) []Secret {
+	index, ok := idx.indexes[name]
+	if !ok {
+		fn, ok := idx.indexers[name]
+		if !ok {
+			return nil
+		}
+		index = make(map[string][]*secret)
+		for _, s := range idx.secrets {
+			for _, k := range fn(s) {
+				index[k] = append(index[k], s)
+			}
+		}
+		idx.indexes[name] = index
+	}
+	matches := index[key]
+	if len(matches) == 0 {
+		return nil
+	}
+	result := make([]Secret, len(matches))
+	for i, s := range matches {
+		result[i] = s
+	}
+	return result
+}
+
+// ByID returns the secret with the given id, or nil if there isn't one.
+func (idx *SecretIndex) ByID(id string) Secret {
+	matches := idx.byIndex("id", id)
+	if len(matches) == 0 {
+		return nil
+	}
+	return matches[0]
+}
+
+// ByOwner returns the secrets owned by tag.
+func (idx *SecretIndex) ByOwner(tag names.Tag) []Secret {
+	return idx.byIndex("owner", tag.String())
+}
+
+// ByLabel returns the secrets owned by tag with the given label.
+func (idx *SecretIndex) ByLabel(owner names.Tag, label string) []Secret {
+	return idx.byIndex("label", ownerLabelKey(owner.String(), label))
+}
+
+// ByConsumer returns the secrets consumed by tag.
+func (idx *SecretIndex) ByConsumer(tag names.Tag) []Secret {
+	return idx.byIndex("consumer", tag.String())
+}
+
+// ByBackend returns the secrets that have at least one revision stored in
+// the backend identified by backendID.
+func (idx *SecretIndex) ByBackend(backendID string) []Secret {
+	return idx.byIndex("backend", backendID)
+}
+
+func idIndexFunc(s *secret) []string {
+	if s.ID_ == "" {
+		return nil
+	}
+	return []string{s.ID_}
+}
+
+func ownerLabelKey(owner, label string) string {
+	return owner + "\x00" + label
+}
+
+func ownerIndexFunc(s *secret) []string {
+	if s.Owner_ == "" {
+		return nil
+	}
+	return []string{s.Owner_}
+}
+
+func labelIndexFunc(s *secret) []string {
+	if s.Owner_ == "" || s.Label_ == "" {
+		return nil
+	}
+	return []string{ownerLabelKey(s.Owner_, s.Label_)}
+}
+
+func consumerIndexFunc(s *secret) []string {
+	var keys []string
+	for _, c := range s.Consumers_ {
+		keys = append(keys, c.Consumer_)
+	}
+	return keys
+}
+
+func backendIndexFunc(s *secret) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, rev := range s.Revisions_ {
+		if rev.BackendId_ == nil || seen[*rev.BackendId_] {
+			continue
+		}
+		seen[*rev.BackendId_] = true
+		keys = append(keys, *rev.BackendId_)
+	}
+	return keys
+}
@@ -0,0 +1,217 @@
+// Copyright 2022 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/juju/names/v4"
+)
+
+// buildRandomSecrets returns n secrets with randomly assigned owners,
+// labels, consumers and backends, drawn from small fixed pools so that
+// collisions (and therefore multi-result index lookups) are common.
+func buildRandomSecrets(r *rand.Rand, n int) []*secret {
+	owners := []names.Tag{
+		names.NewApplicationTag("mysql"),
+		names.NewApplicationTag("postgresql"),
+		names.NewUnitTag("mysql/0"),
+	}
+	consumers := []names.Tag{
+		names.NewUnitTag("wordpress/0"),
+		names.NewUnitTag("wordpress/1"),
+	}
+	labels := []string{"", "db-password", "api-token"}
+	backends := []string{"", "backend-a", "backend-b"}
+
+	result := make([]*secret, n)
+	for i := 0; i < n; i++ {
+		owner := owners[r.Intn(len(owners))]
+		label := labels[r.Intn(len(labels))]
+		backend := backends[r.Intn(len(backends))]
+
+		var revisions []SecretRevisionArgs
+		rev := SecretRevisionArgs{Number: 1}
+		if backend != "" {
+			b := backend
+			rev.BackendId = &b
+		}
+		revisions = append(revisions, rev)
+
+		var consumerArgs []SecretConsumerArgs
+		for _, c := range consumers {
+			if r.Intn(2) == 0 {
+				consumerArgs = append(consumerArgs, SecretConsumerArgs{
+					Consumer:        c,
+					CurrentRevision: 1,
+				})
+			}
+		}
+
+		result[i] = newSecret(SecretArgs{
+			ID:        fmt.Sprintf("secret-%d", i),
+			Owner:     owner,
+			Label:     label,
+			Created:   time.Time{},
+			Updated:   time.Time{},
+			Revisions: revisions,
+			Consumers: consumerArgs,
+		})
+	}
+	return result
+}
+
+func secretIDs(secrets []Secret) []string {
+	ids := make([]string, len(secrets))
+	for i, s := range secrets {
+		ids[i] = s.Id()
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func bruteForceByOwner(all []*secret, tag names.Tag) []string {
+	var ids []string
+	for _, s := range all {
+		if s.Owner_ == tag.String() {
+			ids = append(ids, s.ID_)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func bruteForceByLabel(all []*secret, owner names.Tag, label string) []string {
+	var ids []string
+	for _, s := range all {
+		if s.Owner_ == owner.String() && s.Label_ == label && label != "" {
+			ids = append(ids, s.ID_)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func bruteForceByConsumer(all []*secret, tag names.Tag) []string {
+	var ids []string
+	for _, s := range all {
+		for _, c := range s.Consumers_ {
+			if c.Consumer_ == tag.String() {
+				ids = append(ids, s.ID_)
+			}
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func bruteForceByBackend(all []*secret, backendID string) []string {
+	var ids []string
+	for _, s := range all {
+		for _, rev := range s.Revisions_ {
+			if rev.BackendId_ != nil && *rev.BackendId_ == backendID {
+				ids = append(ids, s.ID_)
+				break
+			}
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func assertStringsEqual(t *testing.T, what string, got, want []string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: got %v, want %v", what, got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("%s: got %v, want %v", what, got, want)
+		}
+	}
+}
+
+// TestSecretIndexAgainstBruteForce builds many random secret populations
+// and checks that every SecretIndex lookup matches a brute-force linear
+// scan over the same population.
+func TestSecretIndexAgainstBruteForce(t *testing.T) {
+	owners := []names.Tag{
+		names.NewApplicationTag("mysql"),
+		names.NewApplicationTag("postgresql"),
+		names.NewUnitTag("mysql/0"),
+	}
+	consumers := []names.Tag{
+		names.NewUnitTag("wordpress/0"),
+		names.NewUnitTag("wordpress/1"),
+	}
+	labels := []string{"db-password", "api-token"}
+	backends := []string{"backend-a", "backend-b"}
+
+	r := rand.New(rand.NewSource(42))
+	for round := 0; round < 20; round++ {
+		n := r.Intn(30) + 1
+		all := buildRandomSecrets(r, n)
+		asInterface := make([]Secret, len(all))
+		for i, s := range all {
+			asInterface[i] = s
+		}
+		idx := NewSecretIndex(asInterface)
+
+		for _, owner := range owners {
+			assertStringsEqual(t, "ByOwner", secretIDs(idx.ByOwner(owner)), bruteForceByOwner(all, owner))
+		}
+		for _, owner := range owners {
+			for _, label := range labels {
+				assertStringsEqual(t, "ByLabel",
+					secretIDs(idx.ByLabel(owner, label)),
+					bruteForceByLabel(all, owner, label))
+			}
+		}
+		for _, consumer := range consumers {
+			assertStringsEqual(t, "ByConsumer", secretIDs(idx.ByConsumer(consumer)), bruteForceByConsumer(all, consumer))
+		}
+		for _, backend := range backends {
+			assertStringsEqual(t, "ByBackend", secretIDs(idx.ByBackend(backend)), bruteForceByBackend(all, backend))
+		}
+		for _, s := range all {
+			if got := idx.ByID(s.ID_); got == nil || got.Id() != s.ID_ {
+				t.Fatalf("ByID(%q): got %v", s.ID_, got)
+			}
+		}
+		if got := idx.ByID("does-not-exist"); got != nil {
+			t.Fatalf("ByID(missing): got %v, want nil", got)
+		}
+	}
+}
+
+// TestSecretIndexInvalidatesOnMutation checks that ByLabel reflects a
+// relabel performed after the index was built, proving the index is
+// invalidated rather than serving stale data.
+func TestSecretIndexInvalidatesOnMutation(t *testing.T) {
+	owner := names.NewApplicationTag("mysql")
+	s := newSecret(SecretArgs{
+		ID:    "secret-0",
+		Owner: owner,
+		Label: "old-label",
+	})
+	idx := NewSecretIndex([]Secret{s})
+
+	if got := secretIDs(idx.ByLabel(owner, "old-label")); len(got) != 1 {
+		t.Fatalf("expected secret indexed under old label, got %v", got)
+	}
+
+	s.Label_ = "new-label"
+	s.setConsumers(nil) // setConsumers, like setRevisions and SetACL, triggers invalidation
+
+	if got := idx.ByLabel(owner, "old-label"); got != nil {
+		t.Fatalf("expected no secrets under stale label, got %v", got)
+	}
+	if got := secretIDs(idx.ByLabel(owner, "new-label")); len(got) != 1 {
+		t.Fatalf("expected secret indexed under new label, got %v", got)
+	}
+}
@@ -33,10 +33,14 @@ type Secret interface {
 	LatestRevision() int
 	LatestExpireTime() *time.Time
 
+	AuditEvents() []SecretAuditEvent
+
 	Validate() error
 }
 
 type secrets struct {
+	// Version should be set to latestSecretVersion whenever this
+	// collection is assembled for export.
 	Version  int       `yaml:"version"`
 	Secrets_ []*secret `yaml:"secrets"`
 }
@@ -57,10 +61,24 @@ type secret struct {
 
 	NextRotateTime_ *time.Time `yaml:"next-rotate-time,omitempty"`
 
+	AuditEvents_ []*secretAuditEvent `yaml:"audit-events,omitempty"`
+
 	// These are updated when revisions are set
 	// and are not exported.
 	LatestRevision_   int        `yaml:"-"`
 	LatestExpireTime_ *time.Time `yaml:"-"`
+
+	// importedLatestRevision_ holds the "latest-revision" value exactly as
+	// decoded from an import source, if the source had one, so Validate
+	// can catch input where it disagrees with the revisions actually
+	// present. It is never set outside of import and plays no part in
+	// LatestRevision_'s normal bookkeeping.
+	importedLatestRevision_ *int
+
+	// index_ is the SecretIndex this secret was built into, if any. It is
+	// invalidated whenever setRevisions, setConsumers or SetACL is called,
+	// and is never exported.
+	index_ *SecretIndex `yaml:"-"`
 }
 
 // Revisions implements secret.
@@ -78,6 +96,7 @@ func (i *secret) setRevisions(args []SecretRevisionArgs) {
 		rev := newSecretRevision(arg)
 		i.Revisions_ = append(i.Revisions_, rev)
 	}
+	i.invalidateIndex()
 }
 
 func (i *secret) updateComputedFields() {
@@ -185,6 +204,37 @@ func (i *secret) setConsumers(args []SecretConsumerArgs) {
 		c := newSecretConsumer(arg)
 		i.Consumers_ = append(i.Consumers_, c)
 	}
+	i.invalidateIndex()
+}
+
+// AuditEvents implements secret.
+func (i *secret) AuditEvents() []SecretAuditEvent {
+	var result []SecretAuditEvent
+	for _, e := range i.AuditEvents_ {
+		result = append(result, e)
+	}
+	return result
+}
+
+func (i *secret) setAuditEvents(args []SecretAuditEventArgs) {
+	i.AuditEvents_ = nil
+	for _, arg := range args {
+		i.AuditEvents_ = append(i.AuditEvents_, newSecretAuditEvent(arg))
+	}
+}
+
+// SetACL replaces the secret's access control list.
+func (i *secret) SetACL(args map[string]SecretAccessArgs) {
+	i.ACL_ = newSecretAccess(args)
+	i.invalidateIndex()
+}
+
+// invalidateIndex drops any SecretIndex this secret was built into, so it
+// is rebuilt lazily against the secret's new state on next lookup.
+func (i *secret) invalidateIndex() {
+	if i.index_ != nil {
+		i.index_.invalidate()
+	}
 }
 
 // SecretArgs is an argument struct used to create a
@@ -201,14 +251,19 @@ type SecretArgs struct {
 	Revisions    []SecretRevisionArgs
 	ACL          map[string]SecretAccessArgs
 	Consumers    []SecretConsumerArgs
+	AuditEvents  []SecretAuditEventArgs
 
 	NextRotateTime *time.Time
 }
 
 func newSecret(args SecretArgs) *secret {
+	version := args.Version
+	if version == 0 {
+		version = latestSecretVersion
+	}
 	secret := &secret{
 		ID_:           args.ID,
-		Version_:      args.Version,
+		Version_:      version,
 		Description_:  args.Description,
 		Label_:        args.Label,
 		RotatePolicy_: args.RotatePolicy,
@@ -225,6 +280,7 @@ func newSecret(args SecretArgs) *secret {
 	}
 	secret.setRevisions(args.Revisions)
 	secret.setConsumers(args.Consumers)
+	secret.setAuditEvents(args.AuditEvents)
 	secret.updateComputedFields()
 	return secret
 }
@@ -250,6 +306,103 @@ func (i *secret) Validate() error {
 			return errors.Wrap(err, errors.NotValidf("secret %q invalid consumer", i.ID_))
 		}
 	}
+	if len(i.AuditEvents_) > 0 {
+		knownRevisions := make(map[int]bool, len(i.Revisions_))
+		for _, rev := range i.Revisions_ {
+			knownRevisions[rev.Number_] = true
+		}
+		for _, event := range i.AuditEvents_ {
+			if err := event.validate(knownRevisions); err != nil {
+				return errors.Wrap(err, errors.NotValidf("secret %q audit event", i.ID_))
+			}
+		}
+	}
+	return nil
+}
+
+// duplicateSecretLabelError is a typed error so callers can test for it
+// with errors.Is, without depending on message text.
+type duplicateSecretLabelError string
+
+// Error implements error.
+func (e duplicateSecretLabelError) Error() string {
+	return string(e)
+}
+
+// ErrDuplicateSecretLabel is returned by validateSecrets when two secrets
+// owned by the same entity share a label, or two consumers of (possibly
+// different) secrets share a consumer label under the same consumer tag.
+const ErrDuplicateSecretLabel = duplicateSecretLabelError("duplicate secret label")
+
+// validateSecrets checks collection-level invariants across all secrets in
+// a model that an individual secret's Validate() cannot check on its own:
+// label uniqueness within an owner or consumer scope, that any imported
+// latest-revision value matches the highest revision number, and (when requireSingleActiveRevision
+// is set) that at most one revision per secret is not obsolete. It is
+// intended to be called from the model's top-level Validate() alongside
+// each secret's own Validate().
+func validateSecrets(secrets []*secret, requireSingleActiveRevision bool) error {
+	ownerLabels := make(map[string]map[string]bool)
+	consumerLabels := make(map[string]map[string]bool)
+	for _, s := range secrets {
+		if s.Label_ != "" {
+			if ownerLabels[s.Owner_] == nil {
+				ownerLabels[s.Owner_] = make(map[string]bool)
+			}
+			if ownerLabels[s.Owner_][s.Label_] {
+				return errors.Annotatef(ErrDuplicateSecretLabel, "owner %q label %q", s.Owner_, s.Label_)
+			}
+			ownerLabels[s.Owner_][s.Label_] = true
+		}
+		for _, c := range s.Consumers_ {
+			if c.Label_ == "" {
+				continue
+			}
+			if consumerLabels[c.Consumer_] == nil {
+				consumerLabels[c.Consumer_] = make(map[string]bool)
+			}
+			if consumerLabels[c.Consumer_][c.Label_] {
+				return errors.Annotatef(ErrDuplicateSecretLabel, "consumer %q label %q", c.Consumer_, c.Label_)
+			}
+			consumerLabels[c.Consumer_][c.Label_] = true
+		}
+		if err := s.validateRevisions(requireSingleActiveRevision); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// validateRevisions checks that any latest-revision value present in an
+// import source matches the highest revision number actually recorded,
+// and, when requireSingleActiveRevision is set, that at most one revision
+// is not obsolete.
+//
+// Note: LatestRevision_ itself is always recomputed from Revisions_ by
+// updateComputedFields, so comparing it to a max freshly computed from the
+// same Revisions_ here would be tautological. importedLatestRevision_ is
+// the value as it appeared in the import source, before any such
+// recomputation, and is what corrupt exports would actually disagree with.
+func (i *secret) validateRevisions(requireSingleActiveRevision bool) error {
+	if len(i.Revisions_) == 0 {
+		return nil
+	}
+	maxRevision := 0
+	active := 0
+	for _, rev := range i.Revisions_ {
+		if rev.Number_ > maxRevision {
+			maxRevision = rev.Number_
+		}
+		if !rev.Obsolete_ {
+			active++
+		}
+	}
+	if i.importedLatestRevision_ != nil && *i.importedLatestRevision_ != maxRevision {
+		return errors.NotValidf("secret %q latest revision %d does not match max revision %d", i.ID_, *i.importedLatestRevision_, maxRevision)
+	}
+	if requireSingleActiveRevision && active > 1 {
+		return errors.NotValidf("secret %q has %d non-obsolete revisions", i.ID_, active)
+	}
 	return nil
 }
 
@@ -266,9 +419,13 @@ func importSecrets(source map[string]interface{}) ([]*secret, error) {
 	return importSecretList(sourceList, version)
 }
 
+// latestSecretVersion is the schema version newly constructed secrets are
+// stamped with, and the version the importer upcasts older exports to
+// before processing them.
+const latestSecretVersion = 2
+
 func importSecretList(sourceList []interface{}, version int) ([]*secret, error) {
-	getFields, ok := secretFieldsFuncs[version]
-	if !ok {
+	if _, ok := secretFieldsFuncs[version]; !ok {
 		return nil, errors.NotValidf("version %d", version)
 	}
 
@@ -278,7 +435,13 @@ func importSecretList(sourceList []interface{}, version int) ([]*secret, error)
 		if !ok {
 			return nil, errors.Errorf("unexpected value for secret %d, %T", i, value)
 		}
-		secret, err := importSecret(source, version, getFields)
+		importVersion := version
+		if importVersion == 1 {
+			source = migrateSecretV1ToV2(source)
+			importVersion = latestSecretVersion
+		}
+		getFields := secretFieldsFuncs[importVersion]
+		secret, err := importSecret(source, importVersion, getFields)
 		if err != nil {
 			return nil, errors.Annotatef(err, "secret %d", i)
 		}
@@ -287,8 +450,51 @@ func importSecretList(sourceList []interface{}, version int) ([]*secret, error)
 	return result, nil
 }
 
+// migrateSecretV1ToV2 upcasts a v1-shaped secret map to v2 shape. Most v2
+// additions (backend revision ids, ACL expiry, consumer revision aliases)
+// are purely additive and already default correctly under the v2 schema's
+// own nested checkers, so they need no help here. Audit events are
+// different: v1 secrets have no audit trail at all, so loading one through
+// the v2 path as-is would silently lose the fact that the secret was ever
+// created. This backfills a single synthetic "created" event from the
+// secret's existing owner, create-time and earliest revision number, so
+// that history isn't worse after the upcast than before it.
+func migrateSecretV1ToV2(source map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(source)+1)
+	for k, v := range source {
+		result[k] = v
+	}
+	if _, ok := result["audit-events"]; ok {
+		return result
+	}
+
+	revisions, ok := result["revisions"].([]interface{})
+	if !ok || len(revisions) == 0 {
+		// No revisions to anchor a synthetic event to: leave audit-events
+		// unset rather than backfill one that would reference a revision
+		// number that doesn't exist.
+		return result
+	}
+	var revisionNumber interface{} = int64(1)
+	if rev, ok := revisions[0].(map[interface{}]interface{}); ok {
+		if n, ok := rev["number"]; ok {
+			revisionNumber = n
+		}
+	}
+	result["audit-events"] = []interface{}{
+		map[interface{}]interface{}{
+			"time":            result["create-time"],
+			"actor":           result["owner"],
+			"action":          SecretActionCreated,
+			"revision-number": revisionNumber,
+		},
+	}
+	return result
+}
+
 var secretFieldsFuncs = map[int]fieldsFunc{
 	1: secretV1Fields,
+	2: secretV2Fields,
 }
 
 func secretV1Fields() (schema.Fields, schema.Defaults) {
@@ -305,16 +511,25 @@ func secretV1Fields() (schema.Fields, schema.Defaults) {
 		"revisions":        schema.List(schema.Any()),
 		"acl":              schema.Map(schema.String(), schema.Any()),
 		"consumers":        schema.List(schema.Any()),
+		"latest-revision":  schema.Int(),
 	}
 	// Some values don't have to be there.
 	defaults := schema.Defaults{
 		"rotate-policy":    schema.Omit,
 		"next-rotate-time": schema.Omit,
 		"consumers":        schema.Omit,
+		"latest-revision":  schema.Omit,
 	}
 	return fields, defaults
 }
 
+func secretV2Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := secretV1Fields()
+	fields["audit-events"] = schema.List(schema.Any())
+	defaults["audit-events"] = schema.Omit
+	return fields, defaults
+}
+
 func importSecret(source map[string]interface{}, importVersion int, fieldFunc func() (schema.Fields, schema.Defaults)) (*secret, error) {
 	fields, defaults := fieldFunc()
 	checker := schema.FieldMap(fields, defaults)
@@ -335,6 +550,10 @@ func importSecret(source map[string]interface{}, importVersion int, fieldFunc fu
 		Updated_:        valid["update-time"].(time.Time).UTC(),
 		NextRotateTime_: fieldToTimePtr(valid, "next-rotate-time"),
 	}
+	if raw, ok := valid["latest-revision"].(int64); ok {
+		n := int(raw)
+		secret.importedLatestRevision_ = &n
+	}
 
 	secretACL, err := importSecretAccess(valid, importVersion)
 	if err != nil {
@@ -354,6 +573,12 @@ func importSecret(source map[string]interface{}, importVersion int, fieldFunc fu
 	}
 	secret.Consumers_ = consumerList
 
+	auditEvents, err := importSecretAuditEvents(valid, importVersion)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	secret.AuditEvents_ = auditEvents
+
 	secret.updateComputedFields()
 	return secret, nil
 }
@@ -362,11 +587,17 @@ func importSecret(source map[string]interface{}, importVersion int, fieldFunc fu
 type SecretAccess interface {
 	Scope() string
 	Role() string
+
+	// Expiry is the time at which this grant lapses, or nil if it does
+	// not expire.
+	Expiry() *time.Time
 }
 
 type secretAccess struct {
 	Scope_ string `yaml:"scope"`
 	Role_  string `yaml:"role"`
+
+	Expiry_ *time.Time `yaml:"expiry,omitempty"`
 }
 
 // SecretAccessArgs is an argument struct used to create a
@@ -374,6 +605,8 @@ type secretAccess struct {
 type SecretAccessArgs struct {
 	Scope string
 	Role  string
+
+	Expiry *time.Time
 }
 
 func newSecretAccess(args map[string]SecretAccessArgs) map[string]*secretAccess {
@@ -383,10 +616,15 @@ func newSecretAccess(args map[string]SecretAccessArgs) map[string]*secretAccess
 	}
 	result = make(map[string]*secretAccess)
 	for subject, access := range args {
-		result[subject] = &secretAccess{
+		entry := &secretAccess{
 			Scope_: access.Scope,
 			Role_:  access.Role,
 		}
+		if access.Expiry != nil {
+			expiry := access.Expiry.UTC()
+			entry.Expiry_ = &expiry
+		}
+		result[subject] = entry
 	}
 	return result
 }
@@ -401,6 +639,11 @@ func (i *secretAccess) Role() string {
 	return i.Role_
 }
 
+// Expiry implements SecretAccess.
+func (i *secretAccess) Expiry() *time.Time {
+	return i.Expiry_
+}
+
 func importSecretAccess(source map[string]interface{}, version int) (map[string]*secretAccess, error) {
 	importFunc, ok := secretAccessDeserializationFuncs[version]
 	if !ok {
@@ -430,6 +673,7 @@ type secretAccessDeserializationFunc func(map[interface{}]interface{}) (*secretA
 
 var secretAccessDeserializationFuncs = map[int]secretAccessDeserializationFunc{
 	1: importSecretAccessV1,
+	2: importSecretAccessV2,
 }
 
 func importSecretAccessV1(source map[interface{}]interface{}) (*secretAccess, error) {
@@ -455,12 +699,45 @@ func importSecretAccessV1(source map[interface{}]interface{}) (*secretAccess, er
 	return access, nil
 }
 
+func importSecretAccessV2(source map[interface{}]interface{}) (*secretAccess, error) {
+	fields := schema.Fields{
+		"scope":  schema.String(),
+		"role":   schema.String(),
+		"expiry": schema.Time(),
+	}
+	defaults := schema.Defaults{
+		"expiry": schema.Omit,
+	}
+
+	checker := schema.FieldMap(fields, defaults)
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "acl v2 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	// From here we know that the map returned from the schema coercion
+	// contains fields of the right type.
+
+	access := &secretAccess{
+		Scope_:  valid["scope"].(string),
+		Role_:   valid["role"].(string),
+		Expiry_: fieldToTimePtr(valid, "expiry"),
+	}
+	return access, nil
+}
+
 // SecretConsumer represents a secret consumer.
 type SecretConsumer interface {
 	Consumer() (names.Tag, error)
 	Label() string
 	CurrentRevision() int
 	LatestRevision() int
+
+	// SubjectRevisionAlias is an optional caller-chosen alias the
+	// consumer uses to refer to CurrentRevision, e.g. a unit's
+	// "pinned" or "tracking" revision name.
+	SubjectRevisionAlias() string
 }
 
 type secretConsumer struct {
@@ -468,6 +745,8 @@ type secretConsumer struct {
 	Label_           string `yaml:"label"`
 	CurrentRevision_ int    `yaml:"current-revision"`
 
+	SubjectRevisionAlias_ string `yaml:"subject-revision-alias,omitempty"`
+
 	// Updated when added to a secret
 	// but not exported.
 	LatestRevision_ int `yaml:"-"`
@@ -479,13 +758,16 @@ type SecretConsumerArgs struct {
 	Consumer        names.Tag
 	Label           string
 	CurrentRevision int
+
+	SubjectRevisionAlias string
 }
 
 func newSecretConsumer(args SecretConsumerArgs) *secretConsumer {
 	return &secretConsumer{
-		Consumer_:        args.Consumer.String(),
-		Label_:           args.Label,
-		CurrentRevision_: args.CurrentRevision,
+		Consumer_:             args.Consumer.String(),
+		Label_:                args.Label,
+		CurrentRevision_:      args.CurrentRevision,
+		SubjectRevisionAlias_: args.SubjectRevisionAlias,
 	}
 }
 
@@ -516,6 +798,11 @@ func (i *secretConsumer) LatestRevision() int {
 	return i.LatestRevision_
 }
 
+// SubjectRevisionAlias implements SecretConsumer.
+func (i *secretConsumer) SubjectRevisionAlias() string {
+	return i.SubjectRevisionAlias_
+}
+
 func importSecretConsumers(source map[string]interface{}, version int) ([]*secretConsumer, error) {
 	importFunc, ok := secretConsumerDeserializationFuncs[version]
 	if !ok {
@@ -545,6 +832,7 @@ type secretConsumerDeserializationFunc func(map[interface{}]interface{}) (*secre
 
 var secretConsumerDeserializationFuncs = map[int]secretConsumerDeserializationFunc{
 	1: importSecretConsumerV1,
+	2: importSecretConsumerV2,
 }
 
 func importSecretConsumerV1(source map[interface{}]interface{}) (*secretConsumer, error) {
@@ -575,6 +863,37 @@ func importSecretConsumerV1(source map[interface{}]interface{}) (*secretConsumer
 	return consumer, nil
 }
 
+func importSecretConsumerV2(source map[interface{}]interface{}) (*secretConsumer, error) {
+	fields := schema.Fields{
+		"consumer":               schema.String(),
+		"label":                  schema.String(),
+		"current-revision":       schema.Int(),
+		"subject-revision-alias": schema.String(),
+	}
+	defaults := schema.Defaults{
+		"label":                  schema.Omit,
+		"subject-revision-alias": schema.Omit,
+	}
+
+	checker := schema.FieldMap(fields, defaults)
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "consumers v2 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	// From here we know that the map returned from the schema coercion
+	// contains fields of the right type.
+
+	consumer := &secretConsumer{
+		Consumer_:             valid["consumer"].(string),
+		Label_:                valid["label"].(string),
+		CurrentRevision_:      int(valid["current-revision"].(int64)),
+		SubjectRevisionAlias_: valid["subject-revision-alias"].(string),
+	}
+	return consumer, nil
+}
+
 // SecretRevision represents a secret revision.
 type SecretRevision interface {
 	Number() int
@@ -585,6 +904,11 @@ type SecretRevision interface {
 	ExpireTime() *time.Time
 	BackendId() *string
 	Content() map[string]string
+
+	// BackendRef returns the id of the backend holding this revision's
+	// content, and the id of the revision within that backend. Both are
+	// empty if the revision's content is stored inline.
+	BackendRef() (backendID, revisionID string)
 }
 
 type secretRevision struct {
@@ -596,6 +920,11 @@ type secretRevision struct {
 	Content_    map[string]string `yaml:"content,omitempty"`
 	BackendId_  *string           `yaml:"backend-id,omitempty"`
 	ExpireTime_ *time.Time        `yaml:"expire-time,omitempty"`
+
+	// BackendRevisionId_ is the id of this revision's content within the
+	// external backend, e.g. a Vault secret version or a Kubernetes
+	// secret resource version.
+	BackendRevisionId_ *string `yaml:"backend-revision-id,omitempty"`
 }
 
 // SecretRevisionArgs is an argument struct used to create a
@@ -609,16 +938,21 @@ type SecretRevisionArgs struct {
 	Content    map[string]string
 	BackendId  *string
 	ExpireTime *time.Time
+
+	// BackendRevisionId is the id of this revision's content within the
+	// external backend referenced by BackendId.
+	BackendRevisionId *string
 }
 
 func newSecretRevision(args SecretRevisionArgs) *secretRevision {
 	revision := &secretRevision{
-		Number_:    args.Number,
-		Created_:   args.Created.UTC(),
-		Updated_:   args.Updated.UTC(),
-		Obsolete_:  args.Obsolete,
-		Content_:   args.Content,
-		BackendId_: args.BackendId,
+		Number_:            args.Number,
+		Created_:           args.Created.UTC(),
+		Updated_:           args.Updated.UTC(),
+		Obsolete_:          args.Obsolete,
+		Content_:           args.Content,
+		BackendId_:         args.BackendId,
+		BackendRevisionId_: args.BackendRevisionId,
 	}
 	if args.ExpireTime != nil {
 		expire := args.ExpireTime.UTC()
@@ -657,6 +991,17 @@ func (i *secretRevision) BackendId() *string {
 	return i.BackendId_
 }
 
+// BackendRef implements SecretRevision.
+func (i *secretRevision) BackendRef() (backendID, revisionID string) {
+	if i.BackendId_ != nil {
+		backendID = *i.BackendId_
+	}
+	if i.BackendRevisionId_ != nil {
+		revisionID = *i.BackendRevisionId_
+	}
+	return backendID, revisionID
+}
+
 // Content implements SecretRevision.
 func (i *secretRevision) Content() map[string]string {
 	return i.Content_
@@ -691,6 +1036,7 @@ type secretRevisionDeserializationFunc func(map[interface{}]interface{}) (*secre
 
 var secretRevisionRangeDeserializationFuncs = map[int]secretRevisionDeserializationFunc{
 	1: importSecretRevisionV1,
+	2: importSecretRevisionV2,
 }
 
 func importSecretRevisionV1(source map[interface{}]interface{}) (*secretRevision, error) {
@@ -733,3 +1079,49 @@ func importSecretRevisionV1(source map[interface{}]interface{}) (*secretRevision
 	}
 	return rev, nil
 }
+
+func importSecretRevisionV2(source map[interface{}]interface{}) (*secretRevision, error) {
+	fields := schema.Fields{
+		"number":              schema.Int(),
+		"create-time":         schema.Time(),
+		"update-time":         schema.Time(),
+		"obsolete":            schema.Bool(),
+		"expire-time":         schema.Time(),
+		"backend-id":          schema.String(),
+		"backend-revision-id": schema.String(),
+		"content":             schema.StringMap(schema.Any()),
+	}
+	defaults := schema.Defaults{
+		"backend-id":          schema.Omit,
+		"backend-revision-id": schema.Omit,
+		"content":             schema.Omit,
+		"expire-time":         schema.Omit,
+		"obsolete":            false,
+	}
+
+	checker := schema.FieldMap(fields, defaults)
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "revisions v2 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	// From here we know that the map returned from the schema coercion
+	// contains fields of the right type.
+
+	rev := &secretRevision{
+		Number_:     int(valid["number"].(int64)),
+		Created_:    valid["create-time"].(time.Time).UTC(),
+		Updated_:    valid["update-time"].(time.Time).UTC(),
+		Obsolete_:   valid["obsolete"].(bool),
+		ExpireTime_: fieldToTimePtr(valid, "expire-time"),
+		Content_:    convertToStringMap(valid["content"]),
+	}
+	if backendId, ok := valid["backend-id"].(string); ok {
+		rev.BackendId_ = &backendId
+	}
+	if backendRevisionId, ok := valid["backend-revision-id"].(string); ok {
+		rev.BackendRevisionId_ = &backendRevisionId
+	}
+	return rev, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2022 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/juju/names/v4"
+)
+
+func TestValidateSecretsDuplicateOwnerLabel(t *testing.T) {
+	owner := names.NewApplicationTag("mysql")
+	a := newSecret(SecretArgs{ID: "secret-a", Owner: owner, Label: "db-password"})
+	b := newSecret(SecretArgs{ID: "secret-b", Owner: owner, Label: "db-password"})
+
+	err := validateSecrets([]*secret{a, b}, false)
+	if err == nil {
+		t.Fatalf("expected a duplicate label error, got nil")
+	}
+	if !errors.Is(err, ErrDuplicateSecretLabel) {
+		t.Fatalf("err = %v, want errors.Is(err, ErrDuplicateSecretLabel)", err)
+	}
+}
+
+func TestValidateSecretsDuplicateConsumerLabel(t *testing.T) {
+	owner := names.NewApplicationTag("mysql")
+	consumer := names.NewUnitTag("wordpress/0")
+	a := newSecret(SecretArgs{
+		ID:    "secret-a",
+		Owner: owner,
+		Consumers: []SecretConsumerArgs{
+			{Consumer: consumer, Label: "wp-secret", CurrentRevision: 1},
+		},
+	})
+	b := newSecret(SecretArgs{
+		ID:    "secret-b",
+		Owner: owner,
+		Consumers: []SecretConsumerArgs{
+			{Consumer: consumer, Label: "wp-secret", CurrentRevision: 1},
+		},
+	})
+
+	err := validateSecrets([]*secret{a, b}, false)
+	if err == nil {
+		t.Fatalf("expected a duplicate label error, got nil")
+	}
+	if !errors.Is(err, ErrDuplicateSecretLabel) {
+		t.Fatalf("err = %v, want errors.Is(err, ErrDuplicateSecretLabel)", err)
+	}
+}
+
+func TestValidateSecretsNoDuplicates(t *testing.T) {
+	owner := names.NewApplicationTag("mysql")
+	a := newSecret(SecretArgs{ID: "secret-a", Owner: owner, Label: "db-password"})
+	b := newSecret(SecretArgs{ID: "secret-b", Owner: owner, Label: "api-token"})
+
+	if err := validateSecrets([]*secret{a, b}, false); err != nil {
+		t.Fatalf("validateSecrets() = %v, want nil", err)
+	}
+}
+
+func TestValidateRevisionsLatestRevisionMismatch(t *testing.T) {
+	s := newSecret(SecretArgs{
+		ID:    "secret-a",
+		Owner: names.NewApplicationTag("mysql"),
+		Revisions: []SecretRevisionArgs{
+			{Number: 1},
+			{Number: 2},
+		},
+	})
+	mismatched := 5
+	s.importedLatestRevision_ = &mismatched
+
+	err := validateSecrets([]*secret{s}, false)
+	if err == nil {
+		t.Fatalf("expected a latest-revision mismatch error, got nil")
+	}
+
+	matching := 2
+	s.importedLatestRevision_ = &matching
+	if err := validateSecrets([]*secret{s}, false); err != nil {
+		t.Fatalf("validateSecrets() = %v, want nil when latest revision matches", err)
+	}
+}
+
+func TestValidateRevisionsRequireSingleActiveRevision(t *testing.T) {
+	s := newSecret(SecretArgs{
+		ID:    "secret-a",
+		Owner: names.NewApplicationTag("mysql"),
+		Revisions: []SecretRevisionArgs{
+			{Number: 1, Obsolete: false},
+			{Number: 2, Obsolete: false},
+		},
+	})
+
+	if err := validateSecrets([]*secret{s}, false); err != nil {
+		t.Fatalf("validateSecrets(requireSingleActiveRevision=false) = %v, want nil", err)
+	}
+	if err := validateSecrets([]*secret{s}, true); err == nil {
+		t.Fatalf("validateSecrets(requireSingleActiveRevision=true) = nil, want an error for two active revisions")
+	}
+}